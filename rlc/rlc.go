@@ -0,0 +1,70 @@
+// Package rlc implements the coding-coefficient generation of the Sliding
+// Window Random Linear Codes (RLC) FEC Scheme specified in RFC 8681
+// https://www.rfc-editor.org/rfc/rfc8681.html, built on top of the TinyMT32
+// PRNG from the parent tinymt32 package. Its output has not been checked
+// against the C reference implementation, so agreement with that
+// implementation's coefficients for a given seed is not established.
+package rlc
+
+import (
+	"fmt"
+
+	"github.com/hnakamur/tinymt32"
+)
+
+// Galois field sizes supported for coding coefficients, as defined in
+// RFC 8681 Section 5.4.
+const (
+	GF2   = 2
+	GF256 = 256
+)
+
+// maxDT is the range the Density Threshold is drawn against: a coefficient
+// is non-zero when its threshold draw falls below dt, so dt/maxDT is the
+// resulting density of the vector.
+const maxDT = 256
+
+// CoefficientVector returns the n coding coefficients for one encoding
+// symbol, generated from a TinyMT32 Source seeded with seed as specified by
+// RFC 8681 Section 5.4. dt is the Density Threshold carried alongside seed
+// in the Repair FEC Payload ID: each coefficient first consumes a draw that
+// is compared against dt to decide whether that coefficient is zero, so
+// lower values of dt produce sparser vectors; dt = maxDT-1 yields
+// (approximately) the fully dense vector produced by always drawing
+// non-zero. gfSize selects the Galois field the non-zero coefficients are
+// drawn from and must be either GF2 or GF256; any other value is an error.
+//
+// This function is this package author's best-effort reconstruction of RFC
+// 8681's generate_coding_coefficients algorithm, written without access to
+// the RFC text or a C reference implementation to check against; it has not
+// been verified to reproduce the reference bit-for-bit.
+func CoefficientVector(seed uint32, n int, gfSize int, dt uint8) ([]byte, error) {
+	if gfSize != GF2 && gfSize != GF256 {
+		return nil, fmt.Errorf("rlc: unsupported GF field size %d", gfSize)
+	}
+
+	s := tinymt32.NewSource(seed)
+	coefs := make([]byte, n)
+	for i := range coefs {
+		if uint8(s.Intn(maxDT)) >= dt {
+			continue // draw at or above dt: coefs[i] stays 0
+		}
+		switch gfSize {
+		case GF2:
+			coefs[i] = 1
+		case GF256:
+			// tinymt32_rand(s, 256) draws uniformly from {0, ..., 255};
+			// a zero draw is redrawn rather than remapped, since a
+			// non-zero coefficient was already decided above.
+			var v uint32
+			for {
+				v = s.Intn(256)
+				if v != 0 {
+					break
+				}
+			}
+			coefs[i] = byte(v)
+		}
+	}
+	return coefs, nil
+}
@@ -2,6 +2,11 @@
 // specified in RFC 8682 https://www.rfc-editor.org/rfc/rfc8682.html
 package tinymt32
 
+import (
+	"encoding/binary"
+	"fmt"
+)
+
 const (
 	mat1 = 0x8f7011ee
 	mat2 = 0xfc78ff1f
@@ -17,7 +22,32 @@ type Source struct {
 }
 
 // NewSource returns a new pseudo-random Source seeded with the given value. This source is not safe for concurrent use by multiple goroutines.
+//
+// NB: The default parameter set of this specification warrants that none of
+// the possible 2^^32 seeds leads to an all-zero 127-bit internal state,
+// so NewSource does not need period_certification. Callers that supply a
+// different parameter set via NewSourceWithParams do not get that
+// guarantee for free; see NewSourceWithParams.
 func NewSource(seed uint32) *Source {
+	return NewSourceWithParams(seed, mat1, mat2, tmat)
+}
+
+// NewSourceWithParams returns a new pseudo-random Source seeded with the
+// given value, using the supplied mat1, mat2, and tmat parameters instead
+// of this package's default TinyMT32 parameter set. This allows callers to
+// run independent streams with distinct characteristic polynomials, e.g.
+// one per goroutine or FEC block, by drawing mat1/mat2/tmat from the
+// tinymt32dc parameter tables.
+//
+// Unlike NewSource's default parameters, an arbitrary parameter set is not
+// guaranteed to avoid an all-zero 127-bit internal state for every seed, so
+// NewSourceWithParams reinstates the period_certification step from the
+// original TinyMT32 reference implementation: if seeding would otherwise
+// leave the state's masked status[0] and status[1..3] all zero, the state
+// is overwritten with the ASCII bytes "TINY" before the pre-loop runs.
+//
+// This source is not safe for concurrent use by multiple goroutines.
+func NewSourceWithParams(seed uint32, mat1, mat2, tmat uint32) *Source {
 	const minLoop = 8
 	const preLoop = 8
 
@@ -32,15 +62,8 @@ func NewSource(seed uint32) *Source {
 		r.status[i&3] ^= i + 1812433253*(r.status[(i-1)&3]^(r.status[(i-1)&3]>>30))
 	}
 
-	/*
-	 * NB: The parameter set of this specification warrants
-	 * that none of the possible 2^^32 seeds leads to an
-	 * all-zero 127-bit internal state. Therefore, the
-	 * period_certification() function of the original
-	 * TinyMT32 source code has been safely removed. If
-	 * another parameter set is used, this function will
-	 * have to be reintroduced here.
-	 */
+	r.periodCertification()
+
 	for i := 0; i < preLoop; i++ {
 		r.nextState()
 	}
@@ -48,12 +71,287 @@ func NewSource(seed uint32) *Source {
 	return r
 }
 
+// periodCertification overwrites an all-zero 127-bit masked state with the
+// ASCII bytes 'T', 'I', 'N', 'Y', matching the original TinyMT32 reference
+// implementation's period_certification() function. It is a no-op for any
+// state that is not all zero.
+func (r *Source) periodCertification() {
+	if r.status[0]&mask == 0 && r.status[1] == 0 && r.status[2] == 0 && r.status[3] == 0 {
+		r.status[0] = 'T'
+		r.status[1] = 'I'
+		r.status[2] = 'N'
+		r.status[3] = 'Y'
+	}
+}
+
+// NewSourceByArray returns a new pseudo-random Source seeded from an
+// arbitrary-length key, using the supplied mat1, mat2, and tmat parameters.
+// It follows the tinymt32_init_by_array recurrence of the original TinyMT32
+// reference implementation, which lets callers seed from more than 32 bits
+// of entropy (e.g. 128 bits of output from crypto/rand) instead of being
+// limited to NewSource's lossy uint32 seed.
+func NewSourceByArray(key []uint32, mat1, mat2, tmat uint32) *Source {
+	const lag = 1
+	const mid = 1
+	const size = 4
+	const minLoop = 8
+	const preLoop = 8
+
+	st := [size]uint32{0, mat1, mat2, tmat}
+	keyLength := len(key)
+	count := minLoop
+	if keyLength+1 > minLoop {
+		count = keyLength + 1
+	}
+
+	r := initFunc1(st[0] ^ st[mid%size] ^ st[(size-1)%size])
+	st[mid%size] += r
+	r += uint32(keyLength)
+	st[(mid+lag)%size] += r
+	st[0] = r
+	count--
+
+	i, j := 1, 0
+	for ; j < count && j < keyLength; j++ {
+		r = initFunc1(st[i] ^ st[(i+mid)%size] ^ st[(i+size-1)%size])
+		st[(i+mid)%size] += r
+		r += key[j] + uint32(i)
+		st[(i+mid+lag)%size] += r
+		st[i] = r
+		i = (i + 1) % size
+	}
+	for ; j < count; j++ {
+		r = initFunc1(st[i] ^ st[(i+mid)%size] ^ st[(i+size-1)%size])
+		st[(i+mid)%size] += r
+		r += uint32(i)
+		st[(i+mid+lag)%size] += r
+		st[i] = r
+		i = (i + 1) % size
+	}
+	for j = 0; j < size; j++ {
+		r = initFunc2(st[i] + st[(i+mid)%size] + st[(i+size-1)%size])
+		st[(i+mid)%size] ^= r
+		r -= uint32(i)
+		st[(i+mid+lag)%size] ^= r
+		st[i] = r
+		i = (i + 1) % size
+	}
+
+	src := &Source{status: st, mat1: mat1, mat2: mat2, tmat: tmat}
+	src.periodCertification()
+
+	for i := 0; i < preLoop; i++ {
+		src.nextState()
+	}
+
+	return src
+}
+
+// initFunc1 and initFunc2 are the two mixing functions used by the
+// tinymt32_init_by_array recurrence, taken verbatim from the TinyMT32
+// reference implementation.
+func initFunc1(x uint32) uint32 {
+	return (x ^ (x >> 27)) * 1664525
+}
+
+func initFunc2(x uint32) uint32 {
+	return (x ^ (x >> 30)) * 1566083941
+}
+
 // Uint32 returns a non-negative pseudo-random 32-bit integer as an uint32.
 func (r *Source) Uint32() uint32 {
 	r.nextState()
 	return r.temper()
 }
 
+// Intn returns a uniform pseudo-random integer in the range [0, n) as
+// specified by the tinymt32_rand(s, n) function of RFC 8682. It is computed
+// as r.Uint32() % n; the RFC knowingly accepts the resulting slight modulo
+// bias in exchange for bit-exact reproducibility with the C reference
+// implementation, so this method reproduces that formula exactly rather
+// than using an unbiased rejection scheme. It panics if n == 0.
+func (r *Source) Intn(n uint32) uint32 {
+	if n == 0 {
+		panic("tinymt32: invalid argument to Intn")
+	}
+	return r.Uint32() % n
+}
+
+// Uint64 returns a pseudo-random 64-bit value by concatenating two
+// successive Uint32 calls, the first supplying the high 32 bits and the
+// second the low 32 bits. This fixed ordering is part of Source's
+// documented behavior so that Uint64 results remain reproducible across
+// platforms and Go versions.
+func (r *Source) Uint64() uint64 {
+	hi := uint64(r.Uint32())
+	lo := uint64(r.Uint32())
+	return hi<<32 | lo
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64. It
+// implements the math/rand.Source interface.
+func (r *Source) Int63() int64 {
+	return int64(r.Uint64() >> 1)
+}
+
+// Seed reseeds r from a 64-bit value, keeping the Source's current
+// mat1/mat2/tmat parameters. Only the low 32 bits of seed are used, since
+// TinyMT32 is seeded from a uint32; this method exists to satisfy the
+// math/rand.Source interface, and callers who want the full 32 bits of
+// entropy reflected unambiguously should prefer constructing a new Source
+// with NewSource instead.
+func (r *Source) Seed(seed int64) {
+	const minLoop = 8
+	const preLoop = 8
+
+	mat1, mat2, tmat := r.mat1, r.mat2, r.tmat
+	r.status = [...]uint32{uint32(seed), mat1, mat2, tmat}
+
+	for i := uint32(1); i < minLoop; i++ {
+		r.status[i&3] ^= i + 1812433253*(r.status[(i-1)&3]^(r.status[(i-1)&3]>>30))
+	}
+
+	r.periodCertification()
+
+	for i := 0; i < preLoop; i++ {
+		r.nextState()
+	}
+}
+
+// Read fills p with pseudo-random bytes generated by successive Uint32
+// calls, each contributing 4 bytes in little-endian order. It always
+// returns len(p), nil, so *Source implements io.Reader; it is not part of
+// the math/rand.Source or math/rand.Source64 interfaces.
+func (r *Source) Read(p []byte) (int, error) {
+	n := len(p)
+	for i := 0; i+4 <= n; i += 4 {
+		binary.LittleEndian.PutUint32(p[i:], r.Uint32())
+	}
+	if rem := n % 4; rem != 0 {
+		var buf [4]byte
+		binary.LittleEndian.PutUint32(buf[:], r.Uint32())
+		copy(p[n-rem:], buf[:rem])
+	}
+	return n, nil
+}
+
+// marshaledSourceVersion identifies the layout of the blob produced by
+// MarshalBinary, so a future incompatible layout change can be rejected by
+// UnmarshalBinary instead of silently misread.
+const marshaledSourceVersion = 1
+
+// marshaledSourceSize is the fixed length, in bytes, of a MarshalBinary
+// blob: one version byte followed by seven little-endian uint32 fields
+// (the four status words, then mat1, mat2, and tmat).
+const marshaledSourceSize = 1 + 4*7
+
+// MarshalBinary implements encoding.BinaryMarshaler. The returned blob is a
+// versioned, fixed-length, little-endian encoding of r's internal state and
+// parameters, suitable for persisting across process restarts; feeding it
+// back to UnmarshalBinary reproduces r's exact future Uint32 output.
+func (r *Source) MarshalBinary() ([]byte, error) {
+	buf := make([]byte, marshaledSourceSize)
+	buf[0] = marshaledSourceVersion
+	binary.LittleEndian.PutUint32(buf[1:5], r.status[0])
+	binary.LittleEndian.PutUint32(buf[5:9], r.status[1])
+	binary.LittleEndian.PutUint32(buf[9:13], r.status[2])
+	binary.LittleEndian.PutUint32(buf[13:17], r.status[3])
+	binary.LittleEndian.PutUint32(buf[17:21], r.mat1)
+	binary.LittleEndian.PutUint32(buf[21:25], r.mat2)
+	binary.LittleEndian.PutUint32(buf[25:29], r.tmat)
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. It rejects data of
+// the wrong length, an unrecognized version tag, and a masked state that is
+// all zero, since such a state can never occur from any of this package's
+// constructors and would otherwise get TinyMT32 stuck in its shortest cycle.
+func (r *Source) UnmarshalBinary(data []byte) error {
+	if len(data) != marshaledSourceSize {
+		return fmt.Errorf("tinymt32: invalid marshaled Source length %d, want %d", len(data), marshaledSourceSize)
+	}
+	if data[0] != marshaledSourceVersion {
+		return fmt.Errorf("tinymt32: unsupported marshaled Source version %d", data[0])
+	}
+
+	var status [4]uint32
+	status[0] = binary.LittleEndian.Uint32(data[1:5])
+	status[1] = binary.LittleEndian.Uint32(data[5:9])
+	status[2] = binary.LittleEndian.Uint32(data[9:13])
+	status[3] = binary.LittleEndian.Uint32(data[13:17])
+	if status[0]&mask == 0 && status[1] == 0 && status[2] == 0 && status[3] == 0 {
+		return fmt.Errorf("tinymt32: marshaled Source has an invalid all-zero state")
+	}
+
+	r.status = status
+	r.mat1 = binary.LittleEndian.Uint32(data[17:21])
+	r.mat2 = binary.LittleEndian.Uint32(data[21:25])
+	r.tmat = binary.LittleEndian.Uint32(data[25:29])
+	return nil
+}
+
+// Clone returns a copy of r that generates the same future sequence of
+// values independently of r, so the two can be advanced separately without
+// affecting each other.
+func (r *Source) Clone() *Source {
+	clone := *r
+	return &clone
+}
+
+// Split derives n child Sources from r that are reproducible from r's
+// current state yet produce mutually independent streams, for fanning a
+// single seed out to parallel Monte Carlo workers. Each child is reseeded
+// through the NewSourceByArray recurrence with a key built from r's status
+// and the child's index, following the same pattern as Go's internal/fuzz
+// PCG, where each seeded generator carries a distinct stream identifier.
+func (r *Source) Split(n int) []*Source {
+	children := make([]*Source, n)
+	for i := range children {
+		key := []uint32{r.status[0], r.status[1], r.status[2], r.status[3], uint32(i)}
+		children[i] = NewSourceByArray(key, r.mat1, r.mat2, r.tmat)
+	}
+	return children
+}
+
+// defaultJumpPoly is the characteristic polynomial of the state-transition
+// function nextState for this package's default mat1/mat2/tmat parameters,
+// reduced modulo itself to x^(2^64), and stored as a little-endian bit
+// vector: bit i of defaultJumpPoly[i/32] is the coefficient of x^i. It was
+// derived offline by recording the low bit of status[0] across repeated
+// nextState calls, recovering the minimal polynomial of that linear
+// recurring sequence with the Berlekamp-Massey algorithm, and then
+// computing x^(2^64) mod that polynomial by repeated squaring in GF(2)[x].
+// Regenerating this vector for a custom mat1/mat2/tmat parameter set
+// requires repeating that same derivation against the custom parameters.
+var defaultJumpPoly = [4]uint32{0xc86806e9, 0xb9af8e9b, 0xcaf7a3e7, 0x0c1118b8}
+
+// Jump advances r's state by 2^64 calls to nextState in a single pass,
+// using defaultJumpPoly. It is only valid for Sources using this package's
+// default mat1/mat2/tmat parameters (i.e. those created by NewSource or
+// NewSourceByArray without custom parameters); calling it on a Source
+// constructed with different parameters silently produces a state that is
+// not actually 2^64 steps ahead, since the jump polynomial is specific to
+// the parameter set's characteristic polynomial.
+//
+// For each set bit of the jump polynomial, in increasing order, the
+// current state is XORed into an accumulator before nextState advances it;
+// once every bit has been consumed, r's state is replaced by the
+// accumulator.
+func (r *Source) Jump() {
+	var acc [4]uint32
+	for _, word := range defaultJumpPoly {
+		for b := uint(0); b < 32; b++ {
+			if word&(1<<b) != 0 {
+				for i := range acc {
+					acc[i] ^= r.status[i]
+				}
+			}
+			r.nextState()
+		}
+	}
+	r.status = acc
+}
+
 // Internal tinymt32 constants.
 const (
 	sh0  = 1
@@ -0,0 +1,314 @@
+package tinymt32
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"testing"
+)
+
+// Compile-time assertions that *Source satisfies the standard library
+// interfaces this package's math/rand support was added for.
+var (
+	_ rand.Source   = (*Source)(nil)
+	_ rand.Source64 = (*Source)(nil)
+)
+
+func TestIntn(t *testing.T) {
+	r := NewSource(1)
+	for i := 0; i < 1000; i++ {
+		if v := r.Intn(7); v >= 7 {
+			t.Fatalf("Intn(7) = %d, want < 7", v)
+		}
+	}
+}
+
+func TestIntnPanicsOnZero(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Intn(0) did not panic")
+		}
+	}()
+	NewSource(1).Intn(0)
+}
+
+func TestUint64Ordering(t *testing.T) {
+	r := NewSource(1)
+	want := r.Clone()
+
+	got := r.Uint64()
+
+	hi := uint64(want.Uint32())
+	lo := uint64(want.Uint32())
+	if wantVal := hi<<32 | lo; got != wantVal {
+		t.Fatalf("Uint64() = %#x, want %#x (high 32 bits from the first Uint32 call, low 32 from the second)", got, wantVal)
+	}
+}
+
+func TestInt63NonNegative(t *testing.T) {
+	r := NewSource(1)
+	for i := 0; i < 1000; i++ {
+		if v := r.Int63(); v < 0 {
+			t.Fatalf("Int63() = %d, want non-negative", v)
+		}
+	}
+}
+
+func TestSeedReseedsInPlace(t *testing.T) {
+	viaNewSource := NewSource(42)
+
+	viaSeed := NewSource(7) // garbage initial seed, overwritten below
+	viaSeed.Seed(42)
+
+	for i := 0; i < 100; i++ {
+		if got, want := viaSeed.Uint32(), viaNewSource.Uint32(); got != want {
+			t.Fatalf("output %d after Seed(42) = %#x, want %#x (same as NewSource(42))", i, got, want)
+		}
+	}
+}
+
+func TestSeedKeepsParams(t *testing.T) {
+	r := NewSourceWithParams(1, 0x11111111, 0x22222222, 0x33333333)
+	r.Seed(42)
+	if r.mat1 != 0x11111111 || r.mat2 != 0x22222222 || r.tmat != 0x33333333 {
+		t.Fatalf("Seed changed mat1/mat2/tmat: got %#x/%#x/%#x", r.mat1, r.mat2, r.tmat)
+	}
+}
+
+func TestReadLittleEndianFullWords(t *testing.T) {
+	r := NewSource(1)
+	want := r.Clone()
+
+	p := make([]byte, 8)
+	n, err := r.Read(p)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if n != len(p) {
+		t.Fatalf("Read returned n = %d, want %d", n, len(p))
+	}
+
+	var wantBytes [8]byte
+	binary.LittleEndian.PutUint32(wantBytes[0:4], want.Uint32())
+	binary.LittleEndian.PutUint32(wantBytes[4:8], want.Uint32())
+	if string(p) != string(wantBytes[:]) {
+		t.Fatalf("Read bytes = %x, want %x", p, wantBytes)
+	}
+}
+
+func TestReadPartialTrailingWord(t *testing.T) {
+	r := NewSource(1)
+	want := r.Clone()
+
+	// 6 bytes: one full word plus a 2-byte tail drawn from a second Uint32.
+	p := make([]byte, 6)
+	if _, err := r.Read(p); err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+
+	var full [4]byte
+	binary.LittleEndian.PutUint32(full[:], want.Uint32())
+	var tail [4]byte
+	binary.LittleEndian.PutUint32(tail[:], want.Uint32())
+
+	wantBytes := append(append([]byte{}, full[:]...), tail[:2]...)
+	if string(p) != string(wantBytes) {
+		t.Fatalf("Read bytes = %x, want %x", p, wantBytes)
+	}
+}
+
+func TestNewSourceWithParamsZeroState(t *testing.T) {
+	// This (seed, mat1, mat2, tmat) tuple was solved for specifically so
+	// that NewSourceWithParams's minLoop seeding loop leaves the masked
+	// 127-bit state all zero, forcing period_certification to fire through
+	// the real seeding path rather than by poking at status directly.
+	const (
+		seed = 0xf434c1c7
+		m1   = 0x90de5650
+		m2   = 0x1c25aefd
+		tm   = 0x882d3866
+	)
+
+	r := NewSourceWithParams(seed, m1, m2, tm)
+
+	// period_certification runs before the preLoop nextState calls, so
+	// replay those here against the known "TINY" state to get the exact
+	// expected post-construction state.
+	want := &Source{status: [...]uint32{'T', 'I', 'N', 'Y'}, mat1: m1, mat2: m2, tmat: tm}
+	for i := 0; i < 8; i++ {
+		want.nextState()
+	}
+
+	if r.status != want.status {
+		t.Fatalf("status = %#v, want %#v", r.status, want.status)
+	}
+}
+
+func TestPeriodCertificationNoOpOnNonZeroState(t *testing.T) {
+	r := &Source{status: [...]uint32{1, 0, 0, 0}}
+	before := r.status
+	r.periodCertification()
+	if r.status != before {
+		t.Fatalf("periodCertification modified a non-zero state: got %#v, want %#v", r.status, before)
+	}
+}
+
+func TestNewSourceByArrayReferenceVector(t *testing.T) {
+	// Known-good outputs for this (key, mat1, mat2, tmat) combination,
+	// computed from an independent reimplementation of the
+	// tinymt32_init_by_array recurrence; pins NewSourceByArray against a
+	// silent transcription error rather than only checking determinism.
+	key := []uint32{0x12345678, 0x9abcdef0, 0x0f0e0d0c, 0x0b0a0908}
+	want := []uint32{
+		0x8485a0d0, 0x371d9b30, 0xa456bfbf, 0xc307ede8,
+		0x4fa9e0db, 0x04e0f8b2, 0xf5b859a8, 0xb7cdd94b,
+	}
+
+	r := NewSourceByArray(key, mat1, mat2, tmat)
+	for i, w := range want {
+		if got := r.Uint32(); got != w {
+			t.Fatalf("output %d = %#x, want %#x", i, got, w)
+		}
+	}
+}
+
+func TestNewSourceByArrayDeterministic(t *testing.T) {
+	key := []uint32{0x12345678, 0x9abcdef0, 0x0f0e0d0c, 0x0b0a0908}
+
+	a := NewSourceByArray(key, mat1, mat2, tmat)
+	b := NewSourceByArray(key, mat1, mat2, tmat)
+
+	for i := 0; i < 100; i++ {
+		if got, want := a.Uint32(), b.Uint32(); got != want {
+			t.Fatalf("output %d diverged for identical keys: got %#x, want %#x", i, got, want)
+		}
+	}
+}
+
+func TestNewSourceByArrayDistinctKeysDiverge(t *testing.T) {
+	a := NewSourceByArray([]uint32{1, 2, 3, 4}, mat1, mat2, tmat)
+	b := NewSourceByArray([]uint32{4, 3, 2, 1}, mat1, mat2, tmat)
+
+	if a.Uint32() == b.Uint32() {
+		t.Fatalf("distinct keys produced the same first output")
+	}
+}
+
+func TestNewSourceByArrayEmptyKey(t *testing.T) {
+	// A zero-length key must not panic and must still produce a
+	// reproducible stream.
+	a := NewSourceByArray(nil, mat1, mat2, tmat)
+	b := NewSourceByArray(nil, mat1, mat2, tmat)
+	if a.Uint32() != b.Uint32() {
+		t.Fatalf("empty-key streams diverged")
+	}
+}
+
+func TestMarshalUnmarshalBinaryRoundTrip(t *testing.T) {
+	r := NewSource(42)
+	// Advance the state so we are not just round-tripping the fresh
+	// post-construction state.
+	for i := 0; i < 5; i++ {
+		r.Uint32()
+	}
+
+	data, err := r.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary: %v", err)
+	}
+
+	restored := &Source{}
+	if err := restored.UnmarshalBinary(data); err != nil {
+		t.Fatalf("UnmarshalBinary: %v", err)
+	}
+
+	for i := 0; i < 100; i++ {
+		if got, want := restored.Uint32(), r.Uint32(); got != want {
+			t.Fatalf("output %d diverged after round-trip: got %#x, want %#x", i, got, want)
+		}
+	}
+}
+
+func TestUnmarshalBinaryRejectsBadInput(t *testing.T) {
+	r := &Source{}
+
+	if err := r.UnmarshalBinary(make([]byte, marshaledSourceSize-1)); err == nil {
+		t.Fatalf("UnmarshalBinary accepted data of the wrong length")
+	}
+
+	badVersion := make([]byte, marshaledSourceSize)
+	badVersion[0] = marshaledSourceVersion + 1
+	if err := r.UnmarshalBinary(badVersion); err == nil {
+		t.Fatalf("UnmarshalBinary accepted an unrecognized version")
+	}
+
+	allZero := make([]byte, marshaledSourceSize)
+	allZero[0] = marshaledSourceVersion
+	if err := r.UnmarshalBinary(allZero); err == nil {
+		t.Fatalf("UnmarshalBinary accepted an all-zero state")
+	}
+}
+
+func TestClone(t *testing.T) {
+	r := NewSource(7)
+	r.Uint32()
+	r.Uint32()
+
+	clone := r.Clone()
+	for i := 0; i < 50; i++ {
+		if got, want := clone.Uint32(), r.Uint32(); got != want {
+			t.Fatalf("clone output %d diverged: got %#x, want %#x", i, got, want)
+		}
+	}
+
+	// Advancing the clone further must not affect r.
+	clone.Uint32()
+	if clone.status == r.status {
+		t.Fatalf("clone and original unexpectedly share state after independent advances")
+	}
+}
+
+func TestSplitDeterministic(t *testing.T) {
+	a := NewSource(99).Split(4)
+	b := NewSource(99).Split(4)
+
+	for i := range a {
+		for j := 0; j < 20; j++ {
+			if got, want := a[i].Uint32(), b[i].Uint32(); got != want {
+				t.Fatalf("child %d output %d diverged: got %#x, want %#x", i, j, got, want)
+			}
+		}
+	}
+}
+
+func TestSplitChildrenDiverge(t *testing.T) {
+	children := NewSource(99).Split(3)
+	seen := make(map[uint32]bool)
+	for i, c := range children {
+		v := c.Uint32()
+		if seen[v] {
+			t.Fatalf("child %d produced a first output already seen from another child", i)
+		}
+		seen[v] = true
+	}
+}
+
+func TestJumpDeterministic(t *testing.T) {
+	a := NewSource(1)
+	b := a.Clone()
+
+	a.Jump()
+	b.Jump()
+
+	if a.status != b.status {
+		t.Fatalf("Jump is not deterministic: got %#v and %#v from identical states", a.status, b.status)
+	}
+}
+
+func TestJumpAdvancesState(t *testing.T) {
+	r := NewSource(1)
+	before := r.status
+	r.Jump()
+	if r.status == before {
+		t.Fatalf("Jump left the state unchanged")
+	}
+}